@@ -0,0 +1,53 @@
+package blip
+
+import "math"
+
+// SetBassShift sets the high-pass filter's shift amount directly. A
+// larger shift moves the DC-blocking corner frequency lower; the default,
+// matching the original blip_buf library, is the bassShift constant.
+func (b *Blip) SetBassShift(shift uint) {
+	if shift > deltaBits {
+		shift = deltaBits
+	}
+	b.bassShift = shift
+}
+
+// SetBass sets the high-pass filter's corner frequency in Hz, converting
+// it to an equivalent shift given the buffer's current sample rate (set
+// via SetRates). Different emulated systems benefit from different
+// DC-blocking corners -- NES is around 30Hz, SNES/Genesis lower -- so this
+// lets callers tune it instead of forking the module.
+func (b *Blip) SetBass(freqHz float64) {
+	b.SetBassShift(bassShiftForFreq(freqHz, b.sampleRate))
+}
+
+// SetBassShift is the StereoBlip equivalent of Blip.SetBassShift, applying
+// the same shift to both channels.
+func (b *StereoBlip) SetBassShift(shift uint) {
+	if shift > deltaBits {
+		shift = deltaBits
+	}
+	b.bassShift = shift
+}
+
+// SetBass is the StereoBlip equivalent of Blip.SetBass.
+func (b *StereoBlip) SetBass(freqHz float64) {
+	b.SetBassShift(bassShiftForFreq(freqHz, b.sampleRate))
+}
+
+// bassShiftForFreq follows the formula blip_buf documents for converting a
+// corner frequency to a shift amount.
+func bassShiftForFreq(freqHz, sampleRate float64) uint {
+	if freqHz <= 0 || sampleRate <= 0 {
+		return 0
+	}
+
+	shift := math.Round(math.Log2(sampleRate / (2 * math.Pi * freqHz)))
+	if shift < 0 {
+		return 0
+	}
+	if shift > deltaBits {
+		return deltaBits
+	}
+	return uint(shift)
+}
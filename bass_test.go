@@ -0,0 +1,58 @@
+package blip
+
+import "testing"
+
+func TestBassShiftForFreq(t *testing.T) {
+	cases := []struct {
+		name       string
+		freqHz     float64
+		sampleRate float64
+		want       uint
+	}{
+		{"typical NES corner", 30, 44100, 8},
+		{"zero freq", 0, 44100, 0},
+		{"negative freq", -30, 44100, 0},
+		{"zero sample rate", 30, 0, 0},
+		{"negative sample rate", 30, -44100, 0},
+		{"corner above deltaBits clamps", 0.001, 44100, deltaBits},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := bassShiftForFreq(c.freqHz, c.sampleRate)
+			if got != c.want {
+				t.Errorf("bassShiftForFreq(%v, %v) = %d, want %d", c.freqHz, c.sampleRate, got, c.want)
+			}
+		})
+	}
+}
+
+func TestSetBassShiftClampsToDeltaBits(t *testing.T) {
+	b := New(32)
+	b.SetBassShift(deltaBits + 10)
+	if b.bassShift != deltaBits {
+		t.Fatalf("SetBassShift: got %d, want clamp to %d", b.bassShift, uint(deltaBits))
+	}
+}
+
+func TestSetBassBeforeSetRatesYieldsZeroShift(t *testing.T) {
+	// sampleRate defaults to 0 until SetRates is called, so SetBass has no
+	// rate to convert freqHz against and falls back to shift 0.
+	b := New(32)
+	b.SetBass(30)
+	if b.bassShift != 0 {
+		t.Fatalf("SetBass before SetRates: got shift %d, want 0", b.bassShift)
+	}
+}
+
+func TestSetBassAfterSetRates(t *testing.T) {
+	b := New(32)
+	if err := b.SetRates(1, 44100); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	b.SetBass(30)
+	want := bassShiftForFreq(30, 44100)
+	if b.bassShift != want {
+		t.Fatalf("SetBass: got shift %d, want %d", b.bassShift, want)
+	}
+}
@@ -15,6 +15,8 @@ type Blip struct {
 	size       int32
 	integrator int32
 	buffer     []buf_t
+	sampleRate float64
+	bassShift  uint
 }
 
 // Creates new buffer that can hold at most sample_count samples. Sets rates
@@ -22,9 +24,10 @@ type Blip struct {
 // buffer, or NULL if insufficient memory.
 func New(size uint) *Blip {
 	m := &Blip{
-		factor: timeUnit / MaxRatio,
-		size:   int32(size),
-		buffer: make([]buf_t, size+bufExtra),
+		factor:    timeUnit / MaxRatio,
+		size:      int32(size),
+		buffer:    make([]buf_t, size+bufExtra),
+		bassShift: bassShift,
 	}
 	m.Clear()
 
@@ -43,6 +46,7 @@ func (b *Blip) Delete() {
 func (b *Blip) SetRates(clockRate, sampleRate float64) error {
 	factor := timeUnit * sampleRate / clockRate
 	b.factor = uint64(factor)
+	b.sampleRate = sampleRate
 
 	if !(0 <= factor-float64(b.factor) && factor-float64(b.factor) < 1) {
 		return errors.New("clockRate exceeds maximum, relative to sampleRate")
@@ -121,87 +125,29 @@ func (b *Blip) removeSamples(count int) {
 	}
 }
 
+// ReadSamples is kept for backward compatibility with callers built
+// against the unsafe.Pointer sink; it's a thin wrapper around
+// ReadSamplesInt16. Prefer ReadSamplesInt16, ReadSamplesFloat32 or
+// ReadSamplesInt32 for new code. out must point to at least count (or
+// 2*count if stereo) contiguous int16s.
 func (b *Blip) ReadSamples(out unsafe.Pointer, count int, stereo bool) int {
 	if count < 0 {
 		return 0
 	}
 
-	if int32(count) > b.avail {
-		count = int(b.avail)
+	step := 1
+	if stereo {
+		step = 2
 	}
 
-	if count > 0 {
-		step := 1
-		if stereo {
-			step = 2
-		}
-		sum := b.integrator
-
-		for i := 0; i < count; i++ {
-			s := sum >> deltaBits // Eliminate fraction
-
-			sum += b.buffer[i]
-
-			s = clamp(s)
-
-			*(*int16)(out) = int16(s)
-			out = unsafe.Add(out, step*2)
-
-			// High-pass filter
-			sum -= s << (deltaBits - bassShift)
-		}
-
-		b.integrator = sum
-
-		b.removeSamples(count)
-	}
-
-	return count
+	return b.ReadSamplesInt16(unsafe.Slice((*int16)(out), count*step), stereo)
 }
 
 func (b *Blip) AddDelta(time uint, delta int) error {
-	fixed := uint32((uint64(time)*b.factor + b.offset) >> preShift)
-	out := b.buffer[b.avail+int32(fixed>>fracBits):]
-
-	phaseShift := fracBits - phaseBits
-	phase := (fixed >> phaseShift) & (phaseCount - 1)
-	in := blStep[phase]
-
-	interp := int((fixed >> (phaseShift - deltaBits)) & (deltaUnit - 1))
-	delta2 := (delta * interp) >> deltaBits
-	delta -= delta2
-
-	if b.avail+int32(fixed>>fracBits) > b.size+endFrameExtra {
-		return errors.New("buffer size was exceeded")
-	}
-
-	next := blStep[phase+1]
-	for i := 0; i < 8; i++ {
-		out[i] += int32(int(in[i])*delta + int(next[i])*delta2)
-	}
-
-	in = blStep[phaseCount-phase]
-	prev := blStep[phaseCount-phase-1]
-	for i := 0; i < 8; i++ {
-		out[8+i] += int32(int(in[7-i])*delta + int(prev[7-i])*delta2)
-	}
-
-	return nil
+	return addDeltaTo(b.buffer, b.avail, b.size, b.factor, b.offset, time, delta)
 }
 
 // Same as blip_add_delta(), but uses faster, lower-quality synthesis.
 func (b *Blip) AddDeltaFast(time uint, delta int) error {
-	fixed := uint((uint64(time)*b.factor + b.offset) >> preShift)
-	out := b.buffer[b.avail+int32(fixed>>fracBits):]
-
-	interp := int((fixed >> (fracBits - deltaBits)) & (deltaUnit - 1))
-	delta2 := delta * interp
-
-	if b.avail+int32(fixed>>fracBits) > b.size+endFrameExtra {
-		return errors.New("buffer size was exceeded")
-	}
-
-	out[7] += int32(delta*deltaUnit - delta2)
-	out[8] += int32(delta2)
-	return nil
+	return addDeltaFastTo(b.buffer, b.avail, b.size, b.factor, b.offset, time, delta)
 }
@@ -0,0 +1,45 @@
+//go:build ignore
+
+// Command oto demonstrates piping a blip.Blip buffer straight into an
+// oto.Player (github.com/hajimehoshi/oto/v2), using blip.Reader as the
+// io.Reader oto's player reads from. It is excluded from normal builds
+// (go:build ignore) since it pulls in oto, a dependency of the example
+// only, not of package blip itself.
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/akatsuki105/blip-go"
+	"github.com/hajimehoshi/oto/v2"
+)
+
+const (
+	clockRate  = 1789773 // e.g. an NES APU clock
+	sampleRate = 44100
+)
+
+func main() {
+	buf := blip.New(sampleRate / 10)
+	if err := buf.SetRates(clockRate, sampleRate); err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, ready, err := oto.NewContext(sampleRate, 1, 2) // mono source, 16-bit
+	if err != nil {
+		log.Fatal(err)
+	}
+	<-ready
+
+	reader := blip.NewReader(buf, blip.FormatInt16, false)
+	player := ctx.NewPlayer(reader)
+	player.Play()
+
+	// A producer goroutine would call buf.AddDelta/AddDeltaFast as the
+	// emulated chip toggles its output, then buf.EndFrame once per
+	// emulated video frame. Here we just keep the player alive.
+	for {
+		time.Sleep(time.Second)
+	}
+}
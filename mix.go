@@ -0,0 +1,71 @@
+package blip
+
+// MixSamples mixes externally rendered 16-bit PCM samples into the buffer
+// at the current read position, compensating the high-pass integrator so
+// that ReadSamples still reconstructs the mixed waveform correctly. This
+// lets callers fold already-rendered audio (CD-DA, PCM, ADPCM, ...) into a
+// buffer that is otherwise fed via AddDelta. At most min(len(in), avail)
+// samples are mixed in.
+//
+// ReadSamples computes sample i from the integrator *before* adding
+// buffer[i] (buffer[i] only affects sample i+1 onward), so the delta
+// between in[i-1] and in[i] is written to buffer[i-1]; the very first
+// transition, which has no earlier buffer slot, is folded straight into
+// the integrator instead.
+func (b *Blip) MixSamples(in []int16) error {
+	count := len(in)
+	if int32(count) > b.avail {
+		count = int(b.avail)
+	}
+
+	if count > 0 {
+		prev := clamp(b.integrator >> deltaBits)
+		for i := 0; i < count; i++ {
+			s := int32(in[i])
+			delta := (s - prev) << deltaBits
+			if i == 0 {
+				b.integrator += delta
+			} else {
+				b.buffer[i-1] += delta
+			}
+			prev = s
+		}
+	}
+
+	return nil
+}
+
+// MixSamples is the stereo equivalent of Blip.MixSamples: inL and inR are
+// mixed into the left and right channels independently, up to
+// min(len(inL), len(inR), avail) samples.
+func (b *StereoBlip) MixSamples(inL, inR []int16) error {
+	count := len(inL)
+	if len(inR) < count {
+		count = len(inR)
+	}
+	if int32(count) > b.avail {
+		count = int(b.avail)
+	}
+
+	if count > 0 {
+		prevL := clamp(b.integratorL >> deltaBits)
+		prevR := clamp(b.integratorR >> deltaBits)
+		for i := 0; i < count; i++ {
+			sl := int32(inL[i])
+			sr := int32(inR[i])
+			deltaL := (sl - prevL) << deltaBits
+			deltaR := (sr - prevR) << deltaBits
+			if i == 0 {
+				b.integratorL += deltaL
+				b.integratorR += deltaR
+			} else {
+				b.bufferL[i-1] += deltaL
+				b.bufferR[i-1] += deltaR
+			}
+			prevL = sl
+			prevR = sr
+		}
+	}
+
+	return nil
+}
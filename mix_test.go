@@ -0,0 +1,33 @@
+package blip
+
+import "testing"
+
+func TestMixSamplesAlignment(t *testing.T) {
+	in := []int16{100, 300, 300, 300, -200, -200, 0, 0}
+
+	b := New(uint(len(in)))
+	b.SetRates(1, 1)
+	if err := b.EndFrame(uint(len(in))); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+
+	if err := b.MixSamples(in); err != nil {
+		t.Fatalf("MixSamples: %v", err)
+	}
+
+	out := make([]int16, len(in))
+	n := b.ReadSamplesInt16(out, false)
+	if n != len(in) {
+		t.Fatalf("ReadSamplesInt16 returned %d samples, want %d", n, len(in))
+	}
+
+	// The high-pass filter causes a small amount of expected decay, but a
+	// mixed sample must show up at its own index -- not shifted into a
+	// neighbour or dropped entirely, which is what the previous off-by-one
+	// did to the first sample.
+	for i, want := range in {
+		if diff := int(out[i]) - int(want); diff < -4 || diff > 4 {
+			t.Errorf("out[%d] = %d, want close to %d", i, out[i], want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package blip
+
+import "testing"
+
+func newTestMixer(t *testing.T, channels int, size uint) *Mixer {
+	t.Helper()
+	m, err := NewMixer(channels, size, 1, 1)
+	if err != nil {
+		t.Fatalf("NewMixer: %v", err)
+	}
+	return m
+}
+
+func feedChannel(t *testing.T, b *Blip, size uint) {
+	t.Helper()
+	for i := uint(0); i < 4; i++ {
+		if err := b.AddDelta(i*8, 20000); err != nil {
+			t.Fatalf("AddDelta: %v", err)
+		}
+	}
+	if err := b.EndFrame(size); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+}
+
+func TestMixerGain(t *testing.T) {
+	const size = 32
+
+	unity := newTestMixer(t, 1, size)
+	feedChannel(t, unity.Channel(0), size)
+	refOut := make([]int16, size*2)
+	refN := unity.Read(refOut)
+
+	half := newTestMixer(t, 1, size)
+	half.SetChannelGain(0, q15One/2)
+	feedChannel(t, half.Channel(0), size)
+	halfOut := make([]int16, size*2)
+	halfN := half.Read(halfOut)
+
+	if refN != halfN {
+		t.Fatalf("sample count mismatch: unity=%d half=%d", refN, halfN)
+	}
+	for i := 0; i < refN; i++ {
+		want := int(refOut[i*2]) / 2
+		got := int(halfOut[i*2])
+		if diff := got - want; diff < -1 || diff > 1 {
+			t.Errorf("sample %d: half-gain=%d, want ~%d (from unity=%d)", i, got, want, refOut[i*2])
+		}
+	}
+}
+
+func TestMixerPan(t *testing.T) {
+	const size = 32
+
+	hardLeft := newTestMixer(t, 1, size)
+	hardLeft.SetChannelPan(0, q15One, 0)
+	feedChannel(t, hardLeft.Channel(0), size)
+	leftOut := make([]int16, size*2)
+	n := hardLeft.Read(leftOut)
+	if n == 0 {
+		t.Fatal("no samples read")
+	}
+	for i := 0; i < n; i++ {
+		if leftOut[i*2+1] != 0 {
+			t.Fatalf("hard-left pan leaked into right channel at %d: %d", i, leftOut[i*2+1])
+		}
+	}
+	anyNonZero := false
+	for i := 0; i < n; i++ {
+		if leftOut[i*2] != 0 {
+			anyNonZero = true
+			break
+		}
+	}
+	if !anyNonZero {
+		t.Fatal("hard-left pan produced silent left channel")
+	}
+
+	hardRight := newTestMixer(t, 1, size)
+	hardRight.SetChannelPan(0, 0, q15One)
+	feedChannel(t, hardRight.Channel(0), size)
+	rightOut := make([]int16, size*2)
+	hardRight.Read(rightOut)
+	for i := 0; i < n; i++ {
+		if rightOut[i*2] != 0 {
+			t.Fatalf("hard-right pan leaked into left channel at %d: %d", i, rightOut[i*2])
+		}
+	}
+}
+
+func TestMixerMute(t *testing.T) {
+	const size = 32
+
+	m := newTestMixer(t, 2, size)
+	feedChannel(t, m.Channel(0), size)
+	feedChannel(t, m.Channel(1), size)
+	m.Mute(1, true)
+
+	muted := newTestMixer(t, 1, size)
+	feedChannel(t, muted.Channel(0), size)
+	refOut := make([]int16, size*2)
+	refN := muted.Read(refOut)
+
+	out := make([]int16, size*2)
+	n := m.Read(out)
+
+	if n != refN {
+		t.Fatalf("sample count mismatch: got %d, want %d", n, refN)
+	}
+	for i := 0; i < n; i++ {
+		if out[i*2] != refOut[i*2] || out[i*2+1] != refOut[i*2+1] {
+			t.Fatalf("sample %d: muted-mix=(%d,%d), want channel-0-only=(%d,%d)",
+				i, out[i*2], out[i*2+1], refOut[i*2], refOut[i*2+1])
+		}
+	}
+
+	if avail := m.Channel(1).SamplesAvail(); avail != 0 {
+		t.Fatalf("muted channel not drained: SamplesAvail()=%d, want 0", avail)
+	}
+}
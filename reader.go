@@ -0,0 +1,102 @@
+package blip
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sync"
+)
+
+// Format selects the sample format a Reader encodes into.
+type Format int
+
+const (
+	FormatInt16 Format = iota
+	FormatFloat32
+)
+
+// Reader adapts a Blip buffer to the io.Reader interface, so it can be
+// plugged directly into oto.Player, ebiten/audio.NewPlayer, or any other
+// io.Reader-based audio sink. See examples/oto for a complete walkthrough
+// of piping a buffer straight into an oto.Player.
+//
+// Reader follows a single-producer/single-consumer contract: one
+// goroutine calls AddDelta/AddDeltaFast and EndFrame on the wrapped Blip
+// while another calls Read, and the caller is responsible for a
+// happens-before edge between an EndFrame and the Read(s) that drain it
+// (e.g. handing frames off over a channel). Reader's own mutex only
+// guards against Read itself being called from more than one goroutine.
+type Reader struct {
+	mu     sync.Mutex
+	buf    *Blip
+	format Format
+	stereo bool
+	mono   []int16
+	f32    []float32
+}
+
+// NewReader wraps b so it can be streamed as PCM samples in the given
+// format. stereo controls whether samples are interleaved L/R or mono.
+func NewReader(b *Blip, format Format, stereo bool) *Reader {
+	return &Reader{buf: b, format: format, stereo: stereo}
+}
+
+func (r *Reader) bytesPerSample() int {
+	n := 2
+	if r.format == FormatFloat32 {
+		n = 4
+	}
+	if r.stereo {
+		n *= 2
+	}
+	return n
+}
+
+// Read implements io.Reader. It never blocks waiting for more samples: if
+// fewer are buffered than p can hold -- the ordinary state of affairs for
+// a real-time audio producer -- it fills as many whole sample frames as
+// are currently available (possibly zero) and returns a nil error, per
+// the standard io.Reader short-read convention. Callers like oto.Player
+// and ebiten's audio backends poll Read in a loop and treat any non-EOF
+// error as fatal, so a partial fill is not itself an error condition.
+func (r *Reader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	frame := r.bytesPerSample()
+	count := len(p) / frame
+	if count == 0 {
+		return 0, nil
+	}
+
+	chans := 1
+	if r.stereo {
+		chans = 2
+	}
+
+	var n int
+	switch r.format {
+	case FormatInt16:
+		if cap(r.mono) < count*chans {
+			r.mono = make([]int16, count*chans)
+		}
+		out := r.mono[:count*chans]
+		n = r.buf.ReadSamplesInt16(out, r.stereo)
+		for i := 0; i < n*chans; i++ {
+			binary.LittleEndian.PutUint16(p[i*2:], uint16(out[i]))
+		}
+	case FormatFloat32:
+		if cap(r.f32) < count*chans {
+			r.f32 = make([]float32, count*chans)
+		}
+		out := r.f32[:count*chans]
+		n = r.buf.ReadSamplesFloat32(out, r.stereo)
+		for i := 0; i < n*chans; i++ {
+			binary.LittleEndian.PutUint32(p[i*4:], math.Float32bits(out[i]))
+		}
+	default:
+		return 0, errors.New("blip: unknown format")
+	}
+
+	return n * frame, nil
+}
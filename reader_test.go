@@ -0,0 +1,99 @@
+package blip
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+func newFedBlip(t *testing.T, size uint) *Blip {
+	t.Helper()
+	b := New(size)
+	if err := b.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	for i := uint(0); i < 4; i++ {
+		if err := b.AddDelta(i*4, 20000); err != nil {
+			t.Fatalf("AddDelta: %v", err)
+		}
+	}
+	if err := b.EndFrame(size); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+	return b
+}
+
+func TestReaderZeroAvailReturnsNilError(t *testing.T) {
+	b := New(32)
+	if err := b.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+
+	r := NewReader(b, FormatInt16, false)
+	p := make([]byte, 64)
+	n, err := r.Read(p)
+	if err != nil {
+		t.Fatalf("Read: unexpected error %v", err)
+	}
+	if n != 0 {
+		t.Fatalf("Read: got n=%d, want 0", n)
+	}
+}
+
+func TestReaderPartialReadInt16(t *testing.T) {
+	const size = 32
+
+	ref := newFedBlip(t, size)
+	avail := ref.SamplesAvail()
+	want := make([]int16, avail)
+	ref.ReadSamplesInt16(want, false)
+
+	b := newFedBlip(t, size)
+	r := NewReader(b, FormatInt16, false)
+
+	// Request far more than is buffered; Read must return a short,
+	// successful read instead of an error.
+	p := make([]byte, (avail+64)*2)
+	n, err := r.Read(p)
+	if err != nil {
+		t.Fatalf("Read: unexpected error %v", err)
+	}
+	if n != avail*2 {
+		t.Fatalf("Read: got n=%d, want %d", n, avail*2)
+	}
+
+	for i := 0; i < avail; i++ {
+		got := int16(binary.LittleEndian.Uint16(p[i*2:]))
+		if got != want[i] {
+			t.Errorf("sample %d: got %d, want %d", i, got, want[i])
+		}
+	}
+}
+
+func TestReaderPartialReadFloat32(t *testing.T) {
+	const size = 32
+
+	ref := newFedBlip(t, size)
+	avail := ref.SamplesAvail()
+	want := make([]float32, avail)
+	ref.ReadSamplesFloat32(want, false)
+
+	b := newFedBlip(t, size)
+	r := NewReader(b, FormatFloat32, false)
+
+	p := make([]byte, (avail+64)*4)
+	n, err := r.Read(p)
+	if err != nil {
+		t.Fatalf("Read: unexpected error %v", err)
+	}
+	if n != avail*4 {
+		t.Fatalf("Read: got n=%d, want %d", n, avail*4)
+	}
+
+	for i := 0; i < avail; i++ {
+		got := math.Float32frombits(binary.LittleEndian.Uint32(p[i*4:]))
+		if got != want[i] {
+			t.Errorf("sample %d: got %v, want %v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package blip
+
+// sampleScale converts a clamped int16-range sample to the [-1, 1] range
+// expected by float32 PCM sinks.
+const sampleScale = 1.0 / 32768.0
+
+// ReadSamplesInt16 is the same as ReadSamples, but reads into a typed
+// slice instead of an unsafe.Pointer sink. out must be at least count
+// samples long (2*count if stereo), where count is SamplesAvail() or less.
+func (b *Blip) ReadSamplesInt16(out []int16, stereo bool) int {
+	step := 1
+	if stereo {
+		step = 2
+	}
+
+	count := len(out) / step
+	if int32(count) > b.avail {
+		count = int(b.avail)
+	}
+
+	if count > 0 {
+		sum := b.integrator
+		pos := 0
+
+		for i := 0; i < count; i++ {
+			s := sum >> deltaBits
+			sum += b.buffer[i]
+			s = clamp(s)
+
+			out[pos] = int16(s)
+			pos += step
+
+			sum -= s << (deltaBits - b.bassShift)
+		}
+
+		b.integrator = sum
+		b.removeSamples(count)
+	}
+
+	return count
+}
+
+// ReadSamplesFloat32 is the same as ReadSamplesInt16, but scales each
+// sample to the [-1, 1] range and skips the int16 cast, so backends that
+// use FormatFloat32LE (oto, ebiten, miniaudio) don't need an extra
+// conversion pass.
+func (b *Blip) ReadSamplesFloat32(out []float32, stereo bool) int {
+	step := 1
+	if stereo {
+		step = 2
+	}
+
+	count := len(out) / step
+	if int32(count) > b.avail {
+		count = int(b.avail)
+	}
+
+	if count > 0 {
+		sum := b.integrator
+		pos := 0
+
+		for i := 0; i < count; i++ {
+			s := sum >> deltaBits
+			sum += b.buffer[i]
+			s = clamp(s)
+
+			out[pos] = float32(s) * sampleScale
+			pos += step
+
+			sum -= s << (deltaBits - b.bassShift)
+		}
+
+		b.integrator = sum
+		b.removeSamples(count)
+	}
+
+	return count
+}
+
+// ReadSamplesInt32 is the same as ReadSamplesInt16, but widens each sample
+// to 32 bits for backends that expect FormatInt32LE without a conversion
+// pass.
+func (b *Blip) ReadSamplesInt32(out []int32, stereo bool) int {
+	step := 1
+	if stereo {
+		step = 2
+	}
+
+	count := len(out) / step
+	if int32(count) > b.avail {
+		count = int(b.avail)
+	}
+
+	if count > 0 {
+		sum := b.integrator
+		pos := 0
+
+		for i := 0; i < count; i++ {
+			s := sum >> deltaBits
+			sum += b.buffer[i]
+			s = clamp(s)
+
+			out[pos] = s
+			pos += step
+
+			sum -= s << (deltaBits - b.bassShift)
+		}
+
+		b.integrator = sum
+		b.removeSamples(count)
+	}
+
+	return count
+}
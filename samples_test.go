@@ -0,0 +1,55 @@
+package blip
+
+import "testing"
+
+func fillBuffer(b testing.TB, n int) *Blip {
+	buf := New(uint(n))
+	if err := buf.SetRates(1, 1); err != nil {
+		b.Fatalf("SetRates: %v", err)
+	}
+	for i := 0; i < n; i++ {
+		if err := buf.AddDelta(uint(i), 1000); err != nil {
+			b.Fatalf("AddDelta: %v", err)
+		}
+	}
+	if err := buf.EndFrame(uint(n)); err != nil {
+		b.Fatalf("EndFrame: %v", err)
+	}
+	return buf
+}
+
+func BenchmarkReadSamplesInt16(b *testing.B) {
+	out := make([]int16, 4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		buf := fillBuffer(b, len(out))
+		b.StartTimer()
+
+		buf.ReadSamplesInt16(out, false)
+	}
+}
+
+func BenchmarkReadSamplesFloat32(b *testing.B) {
+	out := make([]float32, 4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		buf := fillBuffer(b, len(out))
+		b.StartTimer()
+
+		buf.ReadSamplesFloat32(out, false)
+	}
+}
+
+func BenchmarkReadSamplesInt32(b *testing.B) {
+	out := make([]int32, 4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		buf := fillBuffer(b, len(out))
+		b.StartTimer()
+
+		buf.ReadSamplesInt32(out, false)
+	}
+}
@@ -0,0 +1,117 @@
+package blip
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// binaryMagic and binaryVersion tag MarshalBinary's output so future
+// layout changes (e.g. a stereo payload) can be recognized and rejected
+// instead of silently misread.
+const (
+	binaryMagic   uint32 = 0x42_4c_49_50 // "BLIP"
+	binaryVersion uint32 = 1
+
+	stateHeaderLen = 4 + 4 + 8 + 8 + 4 + 4 + 4 + 4 // magic, version, factor, offset, avail, size, integrator, bassShift
+)
+
+// MarshalBinary serializes the buffer's resampling state -- factor,
+// offset, avail, size, integrator, bassShift and the live portion of
+// buffer (avail+bufExtra entries, the only ones ReadSamples still
+// touches) -- into a compact, versioned blob. Emulator frontends can embed
+// this in a save-state so restored audio doesn't pop.
+func (b *Blip) MarshalBinary() ([]byte, error) {
+	live := int(b.avail) + bufExtra
+
+	out := make([]byte, stateHeaderLen+live*4)
+	pos := 0
+
+	binary.LittleEndian.PutUint32(out[pos:], binaryMagic)
+	pos += 4
+	binary.LittleEndian.PutUint32(out[pos:], binaryVersion)
+	pos += 4
+	binary.LittleEndian.PutUint64(out[pos:], b.factor)
+	pos += 8
+	binary.LittleEndian.PutUint64(out[pos:], b.offset)
+	pos += 8
+	binary.LittleEndian.PutUint32(out[pos:], uint32(b.avail))
+	pos += 4
+	binary.LittleEndian.PutUint32(out[pos:], uint32(b.size))
+	pos += 4
+	binary.LittleEndian.PutUint32(out[pos:], uint32(b.integrator))
+	pos += 4
+	binary.LittleEndian.PutUint32(out[pos:], uint32(b.bassShift))
+	pos += 4
+
+	for i := 0; i < live; i++ {
+		binary.LittleEndian.PutUint32(out[pos:], uint32(b.buffer[i]))
+		pos += 4
+	}
+
+	return out, nil
+}
+
+// UnmarshalBinary restores state previously produced by MarshalBinary. The
+// receiver must already have been created (via New) with the same size as
+// the buffer that was marshaled; a different size is rejected with an error.
+func (b *Blip) UnmarshalBinary(data []byte) error {
+	if len(data) < stateHeaderLen {
+		return errors.New("blip: truncated state")
+	}
+
+	pos := 0
+	if magic := binary.LittleEndian.Uint32(data[pos:]); magic != binaryMagic {
+		return errors.New("blip: not a blip state blob")
+	}
+	pos += 4
+
+	if version := binary.LittleEndian.Uint32(data[pos:]); version != binaryVersion {
+		return fmt.Errorf("blip: unsupported state version %d", version)
+	}
+	pos += 4
+
+	factor := binary.LittleEndian.Uint64(data[pos:])
+	pos += 8
+	offset := binary.LittleEndian.Uint64(data[pos:])
+	pos += 8
+	avail := int32(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	size := int32(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	integrator := int32(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+	bassShift := uint(binary.LittleEndian.Uint32(data[pos:]))
+	pos += 4
+
+	if size != b.size {
+		return fmt.Errorf("blip: state size %d does not match buffer size %d", size, b.size)
+	}
+	if avail < 0 || avail > size {
+		return fmt.Errorf("blip: state avail %d out of range for buffer size %d", avail, size)
+	}
+	if bassShift > deltaBits {
+		return fmt.Errorf("blip: state bassShift %d exceeds maximum %d", bassShift, deltaBits)
+	}
+
+	live := int(avail) + bufExtra
+	if len(data) < pos+live*4 {
+		return errors.New("blip: truncated state")
+	}
+
+	b.factor = factor
+	b.offset = offset
+	b.avail = avail
+	b.integrator = integrator
+	b.bassShift = bassShift
+
+	for i := range b.buffer {
+		b.buffer[i] = 0
+	}
+	for i := 0; i < live; i++ {
+		b.buffer[i] = buf_t(binary.LittleEndian.Uint32(data[pos:]))
+		pos += 4
+	}
+
+	return nil
+}
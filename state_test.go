@@ -0,0 +1,97 @@
+package blip
+
+import "testing"
+
+func TestStateRoundTrip(t *testing.T) {
+	b := New(64)
+	if err := b.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	b.SetBassShift(4)
+	for i := 0; i < 16; i++ {
+		if err := b.AddDelta(uint(i*4), 5000); err != nil {
+			t.Fatalf("AddDelta: %v", err)
+		}
+	}
+	if err := b.EndFrame(64); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+
+	blob, err := b.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	want := make([]int16, 32)
+	wantN := b.ReadSamplesInt16(want, false)
+
+	restored := New(64)
+	if err := restored.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	if err := restored.UnmarshalBinary(blob); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	got := make([]int16, 32)
+	gotN := restored.ReadSamplesInt16(got, false)
+
+	if wantN != gotN {
+		t.Fatalf("sample count mismatch: want %d, got %d", wantN, gotN)
+	}
+	for i := 0; i < wantN; i++ {
+		if want[i] != got[i] {
+			t.Fatalf("sample %d: want %d, got %d", i, want[i], got[i])
+		}
+	}
+	if restored.bassShift != 4 {
+		t.Fatalf("bassShift not restored: want 4, got %d", restored.bassShift)
+	}
+}
+
+func TestUnmarshalBinaryRejectsBadInput(t *testing.T) {
+	base := New(64)
+	if err := base.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	if err := base.AddDelta(0, 5000); err != nil {
+		t.Fatalf("AddDelta: %v", err)
+	}
+	if err := base.EndFrame(64); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+	good, err := base.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	corrupt := func(mutate func([]byte)) []byte {
+		blob := make([]byte, len(good))
+		copy(blob, good)
+		mutate(blob)
+		return blob
+	}
+
+	// Header layout: magic(0-3) version(4-7) factor(8-15) offset(16-23)
+	// avail(24-27) size(28-31) integrator(32-35) bassShift(36-39).
+	cases := map[string][]byte{
+		"truncated":     good[:len(good)-1],
+		"bad magic":     corrupt(func(b []byte) { b[0] ^= 0xff }),
+		"bad version":   corrupt(func(b []byte) { b[4] ^= 0xff }),
+		"bad size":      corrupt(func(b []byte) { b[28] ^= 0xff }),
+		"bad avail":     corrupt(func(b []byte) { b[24], b[25], b[26], b[27] = 0xff, 0xff, 0xff, 0x7f }),
+		"bad bassShift": corrupt(func(b []byte) { b[36] = 0xff }),
+	}
+
+	for name, blob := range cases {
+		t.Run(name, func(t *testing.T) {
+			target := New(64)
+			if err := target.SetRates(1, 1); err != nil {
+				t.Fatalf("SetRates: %v", err)
+			}
+			if err := target.UnmarshalBinary(blob); err == nil {
+				t.Fatalf("UnmarshalBinary(%s): want error, got nil", name)
+			}
+		})
+	}
+}
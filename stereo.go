@@ -0,0 +1,240 @@
+package blip
+
+import "errors"
+
+// StereoBlip is a sample buffer that resamples to output rate and
+// accumulates two independent sample streams -- left and right -- until
+// they're read out. The two channels share timing, factor and offset but
+// track separate integrators and delta arrays, so panned or
+// out-of-phase stereo content survives instead of being collapsed into a
+// duplicated mono signal.
+type StereoBlip struct {
+	factor      uint64
+	offset      uint64
+	avail       int32
+	size        int32
+	integratorL int32
+	integratorR int32
+	bufferL     []buf_t
+	bufferR     []buf_t
+	invert      bool
+	sampleRate  float64
+	bassShift   uint
+}
+
+// NewStereo creates a new stereo buffer that can hold at most size samples
+// per channel. Sets rates so that there are blip_max_ratio clocks per
+// sample, same as New.
+func NewStereo(size uint) *StereoBlip {
+	m := &StereoBlip{
+		factor:    timeUnit / MaxRatio,
+		size:      int32(size),
+		bufferL:   make([]buf_t, size+bufExtra),
+		bufferR:   make([]buf_t, size+bufExtra),
+		bassShift: bassShift,
+	}
+	m.Clear()
+
+	return m
+}
+
+// Delete frees buffer. No effect if nil is passed.
+func (b *StereoBlip) Delete() {
+	if b != nil {
+		b = nil
+	}
+}
+
+// SetRates sets approximate input clock rate and output sample rate. For
+// every clock_rate input clocks, approximately sample_rate samples are
+// generated, for both channels.
+func (b *StereoBlip) SetRates(clockRate, sampleRate float64) error {
+	factor := timeUnit * sampleRate / clockRate
+	b.factor = uint64(factor)
+	b.sampleRate = sampleRate
+
+	if !(0 <= factor-float64(b.factor) && factor-float64(b.factor) < 1) {
+		return errors.New("clockRate exceeds maximum, relative to sampleRate")
+	}
+
+	if float64(b.factor) < factor {
+		b.factor++
+	}
+
+	return nil
+}
+
+func (b *StereoBlip) Clear() {
+	b.offset = b.factor / 2
+	b.avail = 0
+	b.integratorL = 0
+	b.integratorR = 0
+	for i := range b.bufferL {
+		b.bufferL[i] = 0
+		b.bufferR[i] = 0
+	}
+}
+
+// ClocksNeeded returns the length of time frame, in clocks, needed to make
+// samples additional samples available.
+func (b *StereoBlip) ClocksNeeded(samples uint) int {
+	if b.avail+int32(samples) > b.size {
+		return 0
+	}
+
+	needed := uint64(samples) * timeUnit
+	if needed < b.offset {
+		return 0
+	}
+
+	return int((needed - b.offset + b.factor - 1) / b.factor)
+}
+
+// EndFrame makes input clocks before t available for reading as output
+// samples on both channels, same semantics as Blip.EndFrame.
+func (b *StereoBlip) EndFrame(t uint) error {
+	off := uint64(t)*b.factor + b.offset
+	b.avail += int32(off >> timeBits)
+	b.offset = off & (timeUnit - 1)
+
+	if b.avail > b.size {
+		return errors.New("buffer size was exceeded")
+	}
+	return nil
+}
+
+// SamplesAvail returns the number of buffered sample frames available for
+// reading.
+func (b *StereoBlip) SamplesAvail() int {
+	return int(b.avail)
+}
+
+// InvertStereo swaps the left/right channels at read time, without
+// touching how deltas were written in.
+func (b *StereoBlip) InvertStereo(invert bool) {
+	b.invert = invert
+}
+
+func (b *StereoBlip) removeSamples(count int) {
+	remain := b.avail + int32(bufExtra) - int32(count)
+	b.avail -= int32(count)
+
+	for i := 0; i < int(remain); i++ {
+		b.bufferL[i] = b.bufferL[count+i]
+		b.bufferR[i] = b.bufferR[count+i]
+	}
+	for i := 0; i < count; i++ {
+		b.bufferL[remain+int32(i)] = 0
+		b.bufferR[remain+int32(i)] = 0
+	}
+}
+
+// ReadSamples reads at most count interleaved L/R sample frames into out,
+// which must be at least 2*count long, applying InvertStereo if set.
+func (b *StereoBlip) ReadSamples(out []int16, count int) int {
+	if count < 0 {
+		return 0
+	}
+
+	if int32(count) > b.avail {
+		count = int(b.avail)
+	}
+
+	if count > 0 {
+		left, right := 0, 1
+		if b.invert {
+			left, right = 1, 0
+		}
+
+		sumL := b.integratorL
+		sumR := b.integratorR
+
+		for i := 0; i < count; i++ {
+			sl := sumL >> deltaBits
+			sr := sumR >> deltaBits
+
+			sumL += b.bufferL[i]
+			sumR += b.bufferR[i]
+
+			sl = clamp(sl)
+			sr = clamp(sr)
+
+			out[i*2+left] = int16(sl)
+			out[i*2+right] = int16(sr)
+
+			sumL -= sl << (deltaBits - b.bassShift)
+			sumR -= sr << (deltaBits - b.bassShift)
+		}
+
+		b.integratorL = sumL
+		b.integratorR = sumR
+
+		b.removeSamples(count)
+	}
+
+	return count
+}
+
+func addDeltaTo(buf []buf_t, avail, size int32, factor, offset uint64, time uint, delta int) error {
+	fixed := uint32((uint64(time)*factor + offset) >> preShift)
+	out := buf[avail+int32(fixed>>fracBits):]
+
+	phaseShift := fracBits - phaseBits
+	phase := (fixed >> phaseShift) & (phaseCount - 1)
+	in := blStep[phase]
+
+	interp := int((fixed >> (phaseShift - deltaBits)) & (deltaUnit - 1))
+	delta2 := (delta * interp) >> deltaBits
+	delta -= delta2
+
+	if avail+int32(fixed>>fracBits) > size+endFrameExtra {
+		return errors.New("buffer size was exceeded")
+	}
+
+	next := blStep[phase+1]
+	for i := 0; i < 8; i++ {
+		out[i] += int32(int(in[i])*delta + int(next[i])*delta2)
+	}
+
+	in = blStep[phaseCount-phase]
+	prev := blStep[phaseCount-phase-1]
+	for i := 0; i < 8; i++ {
+		out[8+i] += int32(int(in[7-i])*delta + int(prev[7-i])*delta2)
+	}
+
+	return nil
+}
+
+func addDeltaFastTo(buf []buf_t, avail, size int32, factor, offset uint64, time uint, delta int) error {
+	fixed := uint((uint64(time)*factor + offset) >> preShift)
+	out := buf[avail+int32(fixed>>fracBits):]
+
+	interp := int((fixed >> (fracBits - deltaBits)) & (deltaUnit - 1))
+	delta2 := delta * interp
+
+	if avail+int32(fixed>>fracBits) > size+endFrameExtra {
+		return errors.New("buffer size was exceeded")
+	}
+
+	out[7] += int32(delta*deltaUnit - delta2)
+	out[8] += int32(delta2)
+	return nil
+}
+
+// AddDeltaStereo adds deltaL and deltaR at time in a single call, writing
+// into the left and right delta arrays respectively.
+func (b *StereoBlip) AddDeltaStereo(time uint, deltaL, deltaR int) error {
+	if err := addDeltaTo(b.bufferL, b.avail, b.size, b.factor, b.offset, time, deltaL); err != nil {
+		return err
+	}
+	return addDeltaTo(b.bufferR, b.avail, b.size, b.factor, b.offset, time, deltaR)
+}
+
+// AddDeltaFastStereo is the same as AddDeltaStereo, but uses the faster,
+// lower-quality synthesis that AddDeltaFast uses.
+func (b *StereoBlip) AddDeltaFastStereo(time uint, deltaL, deltaR int) error {
+	if err := addDeltaFastTo(b.bufferL, b.avail, b.size, b.factor, b.offset, time, deltaL); err != nil {
+		return err
+	}
+	return addDeltaFastTo(b.bufferR, b.avail, b.size, b.factor, b.offset, time, deltaR)
+}
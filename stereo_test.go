@@ -0,0 +1,156 @@
+package blip
+
+import "testing"
+
+func newTestStereo(t *testing.T, size uint) *StereoBlip {
+	t.Helper()
+	b := NewStereo(size)
+	if err := b.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	return b
+}
+
+// TestStereoPan checks that a delta written only to the left channel
+// doesn't bleed into the right channel, and that InvertStereo swaps them.
+func TestStereoPan(t *testing.T) {
+	b := newTestStereo(t, 32)
+
+	if err := b.AddDeltaStereo(0, 10000, 0); err != nil {
+		t.Fatalf("AddDeltaStereo: %v", err)
+	}
+	if err := b.EndFrame(32); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+
+	out := make([]int16, 32*2)
+	n := b.ReadSamples(out, 32)
+	if n == 0 {
+		t.Fatal("no samples read")
+	}
+
+	var maxL, maxR int
+	for i := 0; i < n; i++ {
+		if v := abs(int(out[i*2])); v > maxL {
+			maxL = v
+		}
+		if v := abs(int(out[i*2+1])); v > maxR {
+			maxR = v
+		}
+	}
+	if maxL == 0 {
+		t.Fatal("left channel is silent, expected the panned delta")
+	}
+	if maxR != 0 {
+		t.Fatalf("right channel leaked %d, want 0", maxR)
+	}
+
+	b2 := newTestStereo(t, 32)
+	b2.InvertStereo(true)
+	if err := b2.AddDeltaStereo(0, 10000, 0); err != nil {
+		t.Fatalf("AddDeltaStereo: %v", err)
+	}
+	if err := b2.EndFrame(32); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+	out2 := make([]int16, 32*2)
+	b2.ReadSamples(out2, 32)
+
+	var maxL2, maxR2 int
+	for i := 0; i < n; i++ {
+		if v := abs(int(out2[i*2])); v > maxL2 {
+			maxL2 = v
+		}
+		if v := abs(int(out2[i*2+1])); v > maxR2 {
+			maxR2 = v
+		}
+	}
+	if maxL2 != 0 {
+		t.Fatalf("inverted left channel should be silent, got %d", maxL2)
+	}
+	if maxR2 == 0 {
+		t.Fatal("inverted right channel should carry the panned delta")
+	}
+}
+
+// TestStereoPhase checks that opposite-polarity deltas on L and R produce
+// opposite-signed output instead of being summed away, as would happen if
+// the two channels shared a single integrator.
+func TestStereoPhase(t *testing.T) {
+	b := newTestStereo(t, 32)
+
+	if err := b.AddDeltaStereo(0, 10000, -10000); err != nil {
+		t.Fatalf("AddDeltaStereo: %v", err)
+	}
+	if err := b.EndFrame(32); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+
+	out := make([]int16, 32*2)
+	n := b.ReadSamples(out, 32)
+
+	sawPositiveL, sawNegativeR := false, false
+	for i := 0; i < n; i++ {
+		if out[i*2] > 0 {
+			sawPositiveL = true
+		}
+		if out[i*2+1] < 0 {
+			sawNegativeR = true
+		}
+	}
+	if !sawPositiveL || !sawNegativeR {
+		t.Fatalf("expected out-of-phase L/R, got L=%v R=%v", out[:2*n:2], out[1:2*n:2])
+	}
+}
+
+// TestStereoMonoInterop checks that feeding identical deltas into both
+// channels of a StereoBlip reproduces the same waveform a mono Blip
+// produces for the same deltas, channel for channel.
+func TestStereoMonoInterop(t *testing.T) {
+	const size = 32
+
+	mono := New(size)
+	if err := mono.SetRates(1, 1); err != nil {
+		t.Fatalf("SetRates: %v", err)
+	}
+	stereo := newTestStereo(t, size)
+
+	deltas := []int{10000, -4000, 2500, -8000}
+	for i, d := range deltas {
+		time := uint(i * 4)
+		if err := mono.AddDelta(time, d); err != nil {
+			t.Fatalf("AddDelta: %v", err)
+		}
+		if err := stereo.AddDeltaStereo(time, d, d); err != nil {
+			t.Fatalf("AddDeltaStereo: %v", err)
+		}
+	}
+	if err := mono.EndFrame(size); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+	if err := stereo.EndFrame(size); err != nil {
+		t.Fatalf("EndFrame: %v", err)
+	}
+
+	monoOut := make([]int16, size)
+	n := mono.ReadSamplesInt16(monoOut, false)
+
+	stereoOut := make([]int16, size*2)
+	ns := stereo.ReadSamples(stereoOut, size)
+
+	if n != ns {
+		t.Fatalf("sample counts differ: mono=%d stereo=%d", n, ns)
+	}
+	for i := 0; i < n; i++ {
+		if stereoOut[i*2] != monoOut[i] || stereoOut[i*2+1] != monoOut[i] {
+			t.Fatalf("sample %d: mono=%d stereo=(%d,%d)", i, monoOut[i], stereoOut[i*2], stereoOut[i*2+1])
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}